@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregation // import "go.opentelemetry.io/otel/sdk/metric/aggregation"
+
+import "fmt"
+
+// Base2ExponentialHistogram is an aggregation that summarizes a set of
+// measurements as an histogram with bucket boundaries that increase
+// exponentially, using a base that is an integer power of two. The scale of
+// the histogram is automatically adjusted as measurements are made so that
+// the configured maximum number of buckets is never exceeded.
+type Base2ExponentialHistogram struct {
+	// MaxSize is the maximum number of buckets to use for the histogram.
+	MaxSize int32
+	// MaxScale is the maximum resolution scale to use for the histogram.
+	//
+	// MaxScale has a maximum value of 20. Using a value of 20 means the
+	// width of the widest bucket relative to the narrowest bucket is 2^2^-20.
+	//
+	// MaxScale has a minimum value of -10. Using a value of -10 means only
+	// two buckets will be used.
+	MaxScale int32
+
+	// NoMinMax indicates whether to not record the min and max of the
+	// distribution. By default, these extrema are recorded.
+	//
+	// Recording these extrema for cumulative temporality histograms does not
+	// benefit users. So, if this histogram is used to produce cumulative
+	// metrics, these extrema should not be recorded.
+	NoMinMax bool
+}
+
+const (
+	expoMaxScale = 20
+	expoMinScale = -10
+)
+
+var _ Aggregation = Base2ExponentialHistogram{}
+
+func (e Base2ExponentialHistogram) copy() Aggregation {
+	return Base2ExponentialHistogram{
+		MaxSize:  e.MaxSize,
+		MaxScale: e.MaxScale,
+		NoMinMax: e.NoMinMax,
+	}
+}
+
+func (e Base2ExponentialHistogram) err() error {
+	if e.MaxScale > expoMaxScale {
+		return fmt.Errorf("malformed Base2ExponentialHistogram: MaxScale (%d) exceeds maximum scale (%d)", e.MaxScale, expoMaxScale)
+	}
+	if e.MaxScale < expoMinScale {
+		return fmt.Errorf("malformed Base2ExponentialHistogram: MaxScale (%d) is below minimum scale (%d)", e.MaxScale, expoMinScale)
+	}
+	if e.MaxSize <= 0 {
+		return fmt.Errorf("malformed Base2ExponentialHistogram: MaxSize (%d) is less than or equal to zero", e.MaxSize)
+	}
+	return nil
+}
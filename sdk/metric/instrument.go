@@ -151,6 +151,15 @@ type Stream struct {
 	//
 	// If this slice is empty, all attributes will be kept.
 	AllowAttributeKeys []attribute.Key
+
+	// CardinalityLimit is the maximum number of distinct attribute sets the
+	// stream will aggregate. Any additional attribute sets recorded once
+	// this limit is reached are aggregated into a single overflow attribute
+	// set with the key/value pair `otel.metric.overflow=true`.
+	//
+	// If this is 0 or negative, the OTEL_GO_X_CARDINALITY_LIMIT environment
+	// variable is used instead. If that is also unset, no limit is applied.
+	CardinalityLimit int
 }
 
 // attributeFilter returns an attribute.Filter that only allows attributes
@@ -172,6 +181,20 @@ func (s Stream) attributeFilter() attribute.Filter {
 	}
 }
 
+// filterAttributes splits attrs into the attributes kept for the stream's
+// aggregation and the attributes dropped by AllowAttributeKeys. The dropped
+// attributes are not discarded: they are returned so they can still be
+// attached to a sampled exemplar as FilteredAttributes.
+//
+// If s.AllowAttributeKeys is empty, attrs is returned unchanged and no
+// attributes are reported as dropped.
+func (s Stream) filterAttributes(attrs attribute.Set) (kept attribute.Set, dropped []attribute.KeyValue) {
+	if len(s.AllowAttributeKeys) == 0 {
+		return attrs, nil
+	}
+	return attrs.Filter(s.attributeFilter())
+}
+
 // streamID are the identifying properties of a stream.
 type streamID struct {
 	// Name is the name of the stream.
@@ -195,6 +218,7 @@ type streamID struct {
 
 type int64Inst struct {
 	measures []aggregate.Measure[int64]
+	stream   Stream
 
 	embedded.Int64Counter
 	embedded.Int64UpDownCounter
@@ -219,13 +243,15 @@ func (i *int64Inst) aggregate(ctx context.Context, val int64, s attribute.Set) {
 	if err := ctx.Err(); err != nil {
 		return
 	}
+	kept, dropped := i.stream.filterAttributes(s)
 	for _, in := range i.measures {
-		in(ctx, val, s)
+		in(ctx, val, kept, dropped)
 	}
 }
 
 type float64Inst struct {
 	measures []aggregate.Measure[float64]
+	stream   Stream
 
 	embedded.Float64Counter
 	embedded.Float64UpDownCounter
@@ -250,8 +276,9 @@ func (i *float64Inst) aggregate(ctx context.Context, val float64, s attribute.Se
 	if err := ctx.Err(); err != nil {
 		return
 	}
+	kept, dropped := i.stream.filterAttributes(s)
 	for _, in := range i.measures {
-		in(ctx, val, s)
+		in(ctx, val, kept, dropped)
 	}
 }
 
@@ -277,9 +304,9 @@ var _ metric.Float64ObservableCounter = float64Observable{}
 var _ metric.Float64ObservableUpDownCounter = float64Observable{}
 var _ metric.Float64ObservableGauge = float64Observable{}
 
-func newFloat64Observable(scope instrumentation.Scope, kind InstrumentKind, name, desc, u string, meas []aggregate.Measure[float64]) float64Observable {
+func newFloat64Observable(scope instrumentation.Scope, kind InstrumentKind, name, desc, u string, stream Stream, meas []aggregate.Measure[float64]) float64Observable {
 	return float64Observable{
-		observable: newObservable(scope, kind, name, desc, u, meas),
+		observable: newObservable(scope, kind, name, desc, u, stream, meas),
 	}
 }
 
@@ -296,9 +323,9 @@ var _ metric.Int64ObservableCounter = int64Observable{}
 var _ metric.Int64ObservableUpDownCounter = int64Observable{}
 var _ metric.Int64ObservableGauge = int64Observable{}
 
-func newInt64Observable(scope instrumentation.Scope, kind InstrumentKind, name, desc, u string, meas []aggregate.Measure[int64]) int64Observable {
+func newInt64Observable(scope instrumentation.Scope, kind InstrumentKind, name, desc, u string, stream Stream, meas []aggregate.Measure[int64]) int64Observable {
 	return int64Observable{
-		observable: newObservable(scope, kind, name, desc, u, meas),
+		observable: newObservable(scope, kind, name, desc, u, stream, meas),
 	}
 }
 
@@ -306,10 +333,11 @@ type observable[N int64 | float64] struct {
 	metric.Observable
 	observablID[N]
 
+	stream   Stream
 	measures []aggregate.Measure[N]
 }
 
-func newObservable[N int64 | float64](scope instrumentation.Scope, kind InstrumentKind, name, desc, u string, meas []aggregate.Measure[N]) *observable[N] {
+func newObservable[N int64 | float64](scope instrumentation.Scope, kind InstrumentKind, name, desc, u string, stream Stream, meas []aggregate.Measure[N]) *observable[N] {
 	return &observable[N]{
 		observablID: observablID[N]{
 			name:        name,
@@ -318,14 +346,16 @@ func newObservable[N int64 | float64](scope instrumentation.Scope, kind Instrume
 			unit:        u,
 			scope:       scope,
 		},
+		stream:   stream,
 		measures: meas,
 	}
 }
 
 // observe records the val for the set of attrs.
 func (o *observable[N]) observe(val N, s attribute.Set) {
+	kept, dropped := o.stream.filterAttributes(s)
 	for _, in := range o.measures {
-		in(context.Background(), val, s)
+		in(context.Background(), val, kept, dropped)
 	}
 }
 
@@ -0,0 +1,387 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregate // import "go.opentelemetry.io/otel/sdk/metric/internal/aggregate"
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/internal/exemplar"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// expoHistogramDataPoint is a single data point in an exponential histogram.
+type expoHistogramDataPoint[N int64 | float64] struct {
+	count uint64
+	min   N
+	max   N
+	sum   N
+
+	maxSize  int
+	noMinMax bool
+
+	scale int32
+
+	posBuckets expoBuckets
+	negBuckets expoBuckets
+	zeroCount  uint64
+
+	res exemplar.Reservoir[N]
+}
+
+func newExpoHistogramDataPoint[N int64 | float64](cfg aggregation.Base2ExponentialHistogram, res exemplar.Reservoir[N]) *expoHistogramDataPoint[N] {
+	return &expoHistogramDataPoint[N]{
+		maxSize:  int(cfg.MaxSize),
+		noMinMax: cfg.NoMinMax,
+		scale:    cfg.MaxScale,
+		res:      res,
+	}
+}
+
+// record adds a new measurement to the histogram. It will rescale the
+// buckets if needed to keep them within maxSize.
+func (p *expoHistogramDataPoint[N]) record(v N) {
+	p.count++
+
+	if !p.noMinMax {
+		if v < p.min {
+			p.min = v
+		}
+		if v > p.max {
+			p.max = v
+		}
+	}
+	p.sum += v
+
+	absV := math.Abs(float64(v))
+
+	if absV == 0 {
+		p.zeroCount++
+		return
+	}
+
+	bin := p.getBin(absV)
+
+	bucket := &p.posBuckets
+	if v < 0 {
+		bucket = &p.negBuckets
+	}
+
+	// If the new bin would make the bucket wider than maxSize, downscale
+	// until it fits.
+	if bucket.len() != 0 {
+		low := bucket.startBin
+		if bin < low {
+			low = bin
+		}
+		high := bucket.startBin + int32(bucket.len()) - 1
+		if bin > high {
+			high = bin
+		}
+		if int(high-low)+1 > p.maxSize {
+			delta := p.scaleChange(low, high)
+			p.downscale(delta)
+			bin = p.getBin(absV)
+		}
+	}
+
+	bucket.record(bin)
+}
+
+// getBin returns the bucket index v should be recorded into, given the
+// current scale.
+func (p *expoHistogramDataPoint[N]) getBin(v float64) int32 {
+	frac, exp := math.Frexp(v)
+	if p.scale <= 0 {
+		// Because frac is always in the range [.5, 1), exp is always one
+		// greater than the true exponent of v at scales <= 0.
+		correction := int32(1)
+		if frac == .5 {
+			correction = 2
+		}
+		return (int32(exp) - correction) >> (-p.scale)
+	}
+	return int32(exp)<<p.scale - 1 + int32(math.Log(frac)*scaleFactors[p.scale])
+}
+
+// scaleFactors are constants used in the conversion between exponent and
+// index. They are equivalent to 2^scale / ln(2).
+var scaleFactors = [21]float64{
+	math.Ldexp(math.Log2E, 0),
+	math.Ldexp(math.Log2E, 1),
+	math.Ldexp(math.Log2E, 2),
+	math.Ldexp(math.Log2E, 3),
+	math.Ldexp(math.Log2E, 4),
+	math.Ldexp(math.Log2E, 5),
+	math.Ldexp(math.Log2E, 6),
+	math.Ldexp(math.Log2E, 7),
+	math.Ldexp(math.Log2E, 8),
+	math.Ldexp(math.Log2E, 9),
+	math.Ldexp(math.Log2E, 10),
+	math.Ldexp(math.Log2E, 11),
+	math.Ldexp(math.Log2E, 12),
+	math.Ldexp(math.Log2E, 13),
+	math.Ldexp(math.Log2E, 14),
+	math.Ldexp(math.Log2E, 15),
+	math.Ldexp(math.Log2E, 16),
+	math.Ldexp(math.Log2E, 17),
+	math.Ldexp(math.Log2E, 18),
+	math.Ldexp(math.Log2E, 19),
+	math.Ldexp(math.Log2E, 20),
+}
+
+// scaleChange returns the number of scale reductions needed so the bucket
+// range [low, high] fits within maxSize buckets.
+func (p *expoHistogramDataPoint[N]) scaleChange(low, high int32) int32 {
+	var change int32
+	for high-low >= int32(p.maxSize) {
+		high >>= 1
+		low >>= 1
+		change++
+	}
+	return change
+}
+
+// downscale reduces the scale of the positive and negative buckets by
+// delta, merging adjacent buckets as needed.
+func (p *expoHistogramDataPoint[N]) downscale(delta int32) {
+	if delta == 0 {
+		return
+	}
+	p.scale -= delta
+	p.posBuckets.downscale(delta)
+	p.negBuckets.downscale(delta)
+}
+
+// expoBuckets stores the counts for the positive or negative ranges of an
+// exponential histogram as a dense, contiguous slice.
+type expoBuckets struct {
+	startBin int32
+	counts   []uint64
+}
+
+func (b *expoBuckets) len() int {
+	return len(b.counts)
+}
+
+// record increments the count of the bucket bin falls into, growing the
+// backing slice on either end as needed.
+func (b *expoBuckets) record(bin int32) {
+	if len(b.counts) == 0 {
+		b.startBin = bin
+		b.counts = []uint64{1}
+		return
+	}
+
+	if bin < b.startBin {
+		n := int(b.startBin - bin)
+		counts := make([]uint64, n+len(b.counts))
+		copy(counts[n:], b.counts)
+		counts[0] = 1
+		b.startBin = bin
+		b.counts = counts
+		return
+	}
+
+	if last := b.startBin + int32(len(b.counts)) - 1; bin > last {
+		n := int(bin - last)
+		b.counts = append(b.counts, make([]uint64, n)...)
+	}
+	b.counts[bin-b.startBin]++
+}
+
+// downscale merges adjacent buckets by delta scale reductions.
+func (b *expoBuckets) downscale(delta int32) {
+	if len(b.counts) <= 1 {
+		b.startBin >>= delta
+		return
+	}
+
+	newStart := b.startBin >> delta
+	newEnd := (b.startBin + int32(len(b.counts)) - 1) >> delta
+	counts := make([]uint64, newEnd-newStart+1)
+	for i, c := range b.counts {
+		idx := (b.startBin + int32(i)) >> delta
+		counts[idx-newStart] += c
+	}
+	b.startBin = newStart
+	b.counts = counts
+}
+
+// expoHistogramValues summarizes a set of measurements as an exponential
+// histogram with automatically scaled buckets.
+type expoHistogramValues[N int64 | float64] struct {
+	cfg    aggregation.Base2ExponentialHistogram
+	limit  limiter[*expoHistogramDataPoint[N]]
+	newRes func() exemplar.Reservoir[N]
+
+	values   map[attribute.Set]*expoHistogramDataPoint[N]
+	valuesMu sync.Mutex
+}
+
+func newExpoHistogramValues[N int64 | float64](cfg aggregation.Base2ExponentialHistogram, limit int, r exemplar.Provider[N]) *expoHistogramValues[N] {
+	return &expoHistogramValues[N]{
+		cfg:    cfg,
+		limit:  newLimiter[*expoHistogramDataPoint[N]](limit),
+		newRes: exemplar.FilteredProvider(exemplarFilter(), r),
+		values: make(map[attribute.Set]*expoHistogramDataPoint[N]),
+	}
+}
+
+// Aggregate records the measurement value, scoped by attr, into the
+// exponential histogram, rescaling its buckets if necessary.
+func (s *expoHistogramValues[N]) Aggregate(ctx context.Context, value N, attr attribute.Set, droppedAttr []attribute.KeyValue) {
+	s.valuesMu.Lock()
+	defer s.valuesMu.Unlock()
+
+	attr = s.limit.Attributes(attr, s.values)
+	d, ok := s.values[attr]
+	if !ok {
+		d = newExpoHistogramDataPoint[N](s.cfg, s.newRes())
+		d.min, d.max = value, value
+		s.values[attr] = d
+	}
+	d.record(value)
+	d.res.Offer(ctx, now(), value, droppedAttr)
+}
+
+// newDeltaExponentialHistogram returns an Aggregator that summarizes a set
+// of measurements as an exponential histogram. Each histogram is scoped by
+// attributes and the aggregation cycle the measurements were made in.
+//
+// Each aggregation cycle is treated independently. When the returned
+// Aggregator's Aggregations method is called it will reset all histogram
+// counts to zero.
+func newDeltaExponentialHistogram[N int64 | float64](cfg aggregation.Base2ExponentialHistogram, limit int, r exemplar.Provider[N]) aggregator[N] {
+	return &deltaExponentialHistogram[N]{
+		expoHistogramValues: newExpoHistogramValues[N](cfg, limit, r),
+		start:               now(),
+	}
+}
+
+// deltaExponentialHistogram summarizes a set of measurements made in a
+// single aggregation cycle as an exponential histogram.
+type deltaExponentialHistogram[N int64 | float64] struct {
+	*expoHistogramValues[N]
+
+	start time.Time
+}
+
+func (s *deltaExponentialHistogram[N]) Aggregation() metricdata.Aggregation {
+	s.valuesMu.Lock()
+	defer s.valuesMu.Unlock()
+
+	if len(s.values) == 0 {
+		return nil
+	}
+
+	t := now()
+	h := metricdata.ExponentialHistogram[N]{
+		Temporality: metricdata.DeltaTemporality,
+		DataPoints:  make([]metricdata.ExponentialHistogramDataPoint[N], 0, len(s.values)),
+	}
+	for a, b := range s.values {
+		hdp := expoDataPoint[N](a, b, s.start, t, s.cfg.NoMinMax)
+		b.res.Collect(&hdp.Exemplars)
+		h.DataPoints = append(h.DataPoints, hdp)
+
+		// Unused attribute sets do not report.
+		delete(s.values, a)
+	}
+	// The delta collection cycle resets.
+	s.start = t
+	return h
+}
+
+// newCumulativeExponentialHistogram returns an Aggregator that summarizes a
+// set of measurements as an exponential histogram. Each histogram is scoped
+// by attributes.
+//
+// Each aggregation cycle builds from the previous, the histogram counts are
+// the bucketed counts of all values aggregated since the returned Aggregator
+// was created.
+func newCumulativeExponentialHistogram[N int64 | float64](cfg aggregation.Base2ExponentialHistogram, limit int, r exemplar.Provider[N]) aggregator[N] {
+	return &cumulativeExponentialHistogram[N]{
+		expoHistogramValues: newExpoHistogramValues[N](cfg, limit, r),
+		start:               now(),
+	}
+}
+
+// cumulativeExponentialHistogram summarizes a set of measurements made over
+// all aggregation cycles as an exponential histogram.
+type cumulativeExponentialHistogram[N int64 | float64] struct {
+	*expoHistogramValues[N]
+
+	start time.Time
+}
+
+func (s *cumulativeExponentialHistogram[N]) Aggregation() metricdata.Aggregation {
+	s.valuesMu.Lock()
+	defer s.valuesMu.Unlock()
+
+	if len(s.values) == 0 {
+		return nil
+	}
+
+	t := now()
+	h := metricdata.ExponentialHistogram[N]{
+		Temporality: metricdata.CumulativeTemporality,
+		DataPoints:  make([]metricdata.ExponentialHistogramDataPoint[N], 0, len(s.values)),
+	}
+	for a, b := range s.values {
+		hdp := expoDataPoint[N](a, b, s.start, t, s.cfg.NoMinMax)
+		b.res.Collect(&hdp.Exemplars)
+		h.DataPoints = append(h.DataPoints, hdp)
+	}
+	return h
+}
+
+// expoDataPoint copies d, scoped by attr, into a metricdata data point
+// covering [start, t].
+func expoDataPoint[N int64 | float64](attr attribute.Set, d *expoHistogramDataPoint[N], start, t time.Time, noMinMax bool) metricdata.ExponentialHistogramDataPoint[N] {
+	hdp := metricdata.ExponentialHistogramDataPoint[N]{
+		Attributes:    attr,
+		StartTime:     start,
+		Time:          t,
+		Count:         d.count,
+		Scale:         d.scale,
+		ZeroCount:     d.zeroCount,
+		ZeroThreshold: 0,
+		Sum:           d.sum,
+		PositiveBucket: metricdata.ExponentialBucket{
+			Offset: d.posBuckets.startBin,
+			Counts: copyCounts(d.posBuckets.counts),
+		},
+		NegativeBucket: metricdata.ExponentialBucket{
+			Offset: d.negBuckets.startBin,
+			Counts: copyCounts(d.negBuckets.counts),
+		},
+	}
+	if !noMinMax {
+		hdp.Min = metricdata.NewExtrema(d.min)
+		hdp.Max = metricdata.NewExtrema(d.max)
+	}
+	return hdp
+}
+
+func copyCounts(counts []uint64) []uint64 {
+	c := make([]uint64, len(counts))
+	copy(c, counts)
+	return c
+}
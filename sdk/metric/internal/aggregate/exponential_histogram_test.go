@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/metric/aggregation"
+)
+
+func TestExpoHistogramDataPointRecordSingleBucket(t *testing.T) {
+	p := newExpoHistogramDataPoint[float64](aggregation.Base2ExponentialHistogram{
+		MaxSize:  4,
+		MaxScale: 20,
+	}, nil)
+
+	for i := 0; i < 5; i++ {
+		p.record(1)
+	}
+
+	assert.Equal(t, uint64(5), p.count)
+	assert.Equal(t, 5.0, p.sum)
+	require.Equal(t, 1, p.posBuckets.len())
+	assert.Equal(t, []uint64{5}, p.posBuckets.counts)
+}
+
+func TestExpoHistogramDataPointRecordZero(t *testing.T) {
+	p := newExpoHistogramDataPoint[float64](aggregation.Base2ExponentialHistogram{
+		MaxSize:  4,
+		MaxScale: 20,
+	}, nil)
+
+	p.record(0)
+	p.record(0)
+
+	assert.Equal(t, uint64(2), p.zeroCount)
+	assert.Equal(t, 0, p.posBuckets.len())
+	assert.Equal(t, 0, p.negBuckets.len())
+}
+
+func TestExpoHistogramDataPointRecordNegative(t *testing.T) {
+	p := newExpoHistogramDataPoint[float64](aggregation.Base2ExponentialHistogram{
+		MaxSize:  4,
+		MaxScale: 20,
+	}, nil)
+
+	p.record(-2)
+	p.record(-4)
+
+	assert.Equal(t, uint64(2), p.count)
+	assert.Equal(t, -6.0, p.sum)
+	assert.Equal(t, 0, p.posBuckets.len())
+
+	var total uint64
+	for _, c := range p.negBuckets.counts {
+		total += c
+	}
+	assert.Equal(t, uint64(2), total)
+}
+
+// TestExpoHistogramDataPointDownscale records enough distinct powers of two
+// that the bucket range would exceed maxSize, forcing the scale to be
+// reduced so everything still fits in maxSize buckets.
+func TestExpoHistogramDataPointDownscale(t *testing.T) {
+	p := newExpoHistogramDataPoint[float64](aggregation.Base2ExponentialHistogram{
+		MaxSize:  2,
+		MaxScale: 0,
+	}, nil)
+
+	for _, v := range []float64{1, 2, 4, 8} {
+		p.record(v)
+	}
+
+	assert.LessOrEqual(t, p.posBuckets.len(), 2)
+	assert.Less(t, p.scale, int32(0))
+
+	var total uint64
+	for _, c := range p.posBuckets.counts {
+		total += c
+	}
+	assert.Equal(t, uint64(4), total)
+}
+
+// TestExpoHistogramDataPointRecordLowSideExtension records values that grow
+// the bucket upward first and then offers one that extends it on the low
+// side, which previously skipped the downscale because the span check
+// compared against startBin instead of the bucket's actual high end.
+func TestExpoHistogramDataPointRecordLowSideExtension(t *testing.T) {
+	p := newExpoHistogramDataPoint[float64](aggregation.Base2ExponentialHistogram{
+		MaxSize:  4,
+		MaxScale: 0,
+	}, nil)
+
+	for _, v := range []float64{2, 4, 8, 16, 1} {
+		p.record(v)
+	}
+
+	assert.LessOrEqual(t, p.posBuckets.len(), 4)
+
+	var total uint64
+	for _, c := range p.posBuckets.counts {
+		total += c
+	}
+	assert.Equal(t, uint64(5), total)
+}
+
+func TestExpoBucketsRecordGrowsBothDirections(t *testing.T) {
+	var b expoBuckets
+
+	b.record(5)
+	b.record(3)
+	b.record(7)
+
+	require.Equal(t, int32(3), b.startBin)
+	require.Equal(t, []uint64{1, 0, 1, 0, 1}, b.counts)
+}
+
+func TestExpoBucketsDownscaleMergesAdjacentBins(t *testing.T) {
+	b := expoBuckets{startBin: 0, counts: []uint64{1, 1, 1, 1}}
+
+	b.downscale(1)
+
+	assert.Equal(t, int32(0), b.startBin)
+	assert.Equal(t, []uint64{2, 2}, b.counts)
+}
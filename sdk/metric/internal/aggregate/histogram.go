@@ -15,12 +15,15 @@
 package aggregate // import "go.opentelemetry.io/otel/sdk/metric/internal/aggregate"
 
 import (
+	"context"
 	"sort"
 	"sync"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/internal/exemplar"
+	"go.opentelemetry.io/otel/sdk/metric/internal/x"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 )
 
@@ -29,11 +32,13 @@ type buckets[N int64 | float64] struct {
 	count    uint64
 	sum      N
 	min, max N
+
+	res exemplar.Reservoir[N]
 }
 
-// newBuckets returns buckets with n bins.
-func newBuckets[N int64 | float64](n int) *buckets[N] {
-	return &buckets[N]{counts: make([]uint64, n)}
+// newBuckets returns buckets with n bins that sample exemplars with res.
+func newBuckets[N int64 | float64](n int, res exemplar.Reservoir[N]) *buckets[N] {
+	return &buckets[N]{counts: make([]uint64, n), res: res}
 }
 
 func (b *buckets[N]) bin(idx int, value N) {
@@ -51,12 +56,14 @@ func (b *buckets[N]) bin(idx int, value N) {
 // explicitly defined buckets.
 type histValues[N int64 | float64] struct {
 	bounds []float64
+	limit  limiter[*buckets[N]]
+	newRes func() exemplar.Reservoir[N]
 
 	values   map[attribute.Set]*buckets[N]
 	valuesMu sync.Mutex
 }
 
-func newHistValues[N int64 | float64](bounds []float64) *histValues[N] {
+func newHistValues[N int64 | float64](bounds []float64, limit int, r exemplar.Provider[N]) *histValues[N] {
 	// The responsibility of keeping all buckets correctly associated with the
 	// passed boundaries is ultimately this type's responsibility. Make a copy
 	// here so we can always guarantee this. Or, in the case of failure, have
@@ -66,13 +73,26 @@ func newHistValues[N int64 | float64](bounds []float64) *histValues[N] {
 	sort.Float64s(b)
 	return &histValues[N]{
 		bounds: b,
+		limit:  newLimiter[*buckets[N]](limit),
+		newRes: exemplar.FilteredProvider(exemplarFilter(), r),
 		values: make(map[attribute.Set]*buckets[N]),
 	}
 }
 
+// exemplarFilter returns the Filter that should gate every Reservoir built
+// for a new aggregation: AlwaysOffFilter unless the OTEL_GO_X_EXEMPLARS
+// experimental feature gate is enabled, in which case OTEL_METRICS_EXEMPLAR_FILTER
+// is consulted as usual.
+func exemplarFilter() exemplar.Filter {
+	if !x.Exemplars.Enabled() {
+		return exemplar.AlwaysOffFilter
+	}
+	return exemplar.FilterFromEnv()
+}
+
 // Aggregate records the measurement value, scoped by attr, and aggregates it
 // into a histogram.
-func (s *histValues[N]) Aggregate(value N, attr attribute.Set) {
+func (s *histValues[N]) Aggregate(ctx context.Context, value N, attr attribute.Set, droppedAttr []attribute.KeyValue) {
 	// This search will return an index in the range [0, len(s.bounds)], where
 	// it will return len(s.bounds) if value is greater than the last element
 	// of s.bounds. This aligns with the buckets in that the length of buckets
@@ -83,6 +103,7 @@ func (s *histValues[N]) Aggregate(value N, attr attribute.Set) {
 	s.valuesMu.Lock()
 	defer s.valuesMu.Unlock()
 
+	attr = s.limit.Attributes(attr, s.values)
 	b, ok := s.values[attr]
 	if !ok {
 		// N+1 buckets. For example:
@@ -92,12 +113,13 @@ func (s *histValues[N]) Aggregate(value N, attr attribute.Set) {
 		// Then,
 		//
 		//   buckets = (-∞, 0], (0, 5.0], (5.0, 10.0], (10.0, +∞)
-		b = newBuckets[N](len(s.bounds) + 1)
+		b = newBuckets[N](len(s.bounds)+1, s.newRes())
 		// Ensure min and max are recorded values (not zero), for new buckets.
 		b.min, b.max = value, value
 		s.values[attr] = b
 	}
 	b.bin(idx, value)
+	b.res.Offer(ctx, now(), value, droppedAttr)
 }
 
 // newDeltaHistogram returns an Aggregator that summarizes a set of
@@ -107,9 +129,9 @@ func (s *histValues[N]) Aggregate(value N, attr attribute.Set) {
 // Each aggregation cycle is treated independently. When the returned
 // Aggregator's Aggregations method is called it will reset all histogram
 // counts to zero.
-func newDeltaHistogram[N int64 | float64](cfg aggregation.ExplicitBucketHistogram) aggregator[N] {
+func newDeltaHistogram[N int64 | float64](cfg aggregation.ExplicitBucketHistogram, limit int, r exemplar.Provider[N]) aggregator[N] {
 	return &deltaHistogram[N]{
-		histValues: newHistValues[N](cfg.Boundaries),
+		histValues: newHistValues[N](cfg.Boundaries, limit, r),
 		noMinMax:   cfg.NoMinMax,
 		start:      now(),
 	}
@@ -154,6 +176,7 @@ func (s *deltaHistogram[N]) Aggregation() metricdata.Aggregation {
 			hdp.Min = metricdata.NewExtrema(b.min)
 			hdp.Max = metricdata.NewExtrema(b.max)
 		}
+		b.res.Collect(&hdp.Exemplars)
 		h.DataPoints = append(h.DataPoints, hdp)
 
 		// Unused attribute sets do not report.
@@ -170,9 +193,9 @@ func (s *deltaHistogram[N]) Aggregation() metricdata.Aggregation {
 // Each aggregation cycle builds from the previous, the histogram counts are
 // the bucketed counts of all values aggregated since the returned Aggregator
 // was created.
-func newCumulativeHistogram[N int64 | float64](cfg aggregation.ExplicitBucketHistogram) aggregator[N] {
+func newCumulativeHistogram[N int64 | float64](cfg aggregation.ExplicitBucketHistogram, limit int, r exemplar.Provider[N]) aggregator[N] {
 	return &cumulativeHistogram[N]{
-		histValues: newHistValues[N](cfg.Boundaries),
+		histValues: newHistValues[N](cfg.Boundaries, limit, r),
 		noMinMax:   cfg.NoMinMax,
 		start:      now(),
 	}
@@ -225,11 +248,8 @@ func (s *cumulativeHistogram[N]) Aggregation() metricdata.Aggregation {
 			hdp.Min = metricdata.NewExtrema(b.min)
 			hdp.Max = metricdata.NewExtrema(b.max)
 		}
+		b.res.Collect(&hdp.Exemplars)
 		h.DataPoints = append(h.DataPoints, hdp)
-		// TODO (#3006): This will use an unbounded amount of memory if there
-		// are unbounded number of attribute sets being aggregated. Attribute
-		// sets that become "stale" need to be forgotten so this will not
-		// overload the system.
 	}
 	return h
 }
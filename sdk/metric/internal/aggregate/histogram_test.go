@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/sdk/metric/internal/x"
+)
+
+func TestExemplarFilterDisabledByDefault(t *testing.T) {
+	t.Setenv(x.Exemplars.Key, "")
+	t.Setenv("OTEL_METRICS_EXEMPLAR_FILTER", "always_on")
+
+	assert.False(t, exemplarFilter()(context.Background()), "exemplars are opt-in via OTEL_GO_X_EXEMPLARS")
+}
+
+func TestExemplarFilterHonorsExemplarFilterEnvWhenEnabled(t *testing.T) {
+	t.Setenv(x.Exemplars.Key, "true")
+	t.Setenv("OTEL_METRICS_EXEMPLAR_FILTER", "always_off")
+
+	assert.False(t, exemplarFilter()(context.Background()))
+
+	t.Setenv("OTEL_METRICS_EXEMPLAR_FILTER", "always_on")
+
+	assert.True(t, exemplarFilter()(context.Background()))
+}
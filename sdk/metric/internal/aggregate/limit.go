@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregate // import "go.opentelemetry.io/otel/sdk/metric/internal/aggregate"
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/internal/x"
+)
+
+// overflowSet is the attribute set used to record a measurement when adding
+// the measurement's original attribute set would exceed the aggregator's
+// configured cardinality limit.
+var overflowSet = attribute.NewSet(attribute.Bool("otel.metric.overflow", true))
+
+// limiter limits the number of attribute sets an aggregator aggregates.
+type limiter[V any] struct {
+	// aggLimit is the maximum number of attribute sets that can be
+	// aggregated. Any additional sets will be aggregated in to a single
+	// overflow set. If this limit is 0 or less there is no limit.
+	aggLimit int
+}
+
+// newLimiter returns a new limiter that limits an aggregation to aggLimit
+// attribute sets. If aggLimit is 0 or less, the OTEL_GO_X_CARDINALITY_LIMIT
+// experimental feature gate is consulted for a default limit before
+// falling back to no limit.
+func newLimiter[V any](aggLimit int) limiter[V] {
+	if aggLimit <= 0 {
+		if l, ok := x.CardinalityLimit.Lookup(); ok {
+			aggLimit = l
+		}
+	}
+	return limiter[V]{aggLimit: aggLimit}
+}
+
+// Attributes checks if adding a measurement for attrs will exceed the
+// limit, and returns the attribute set that should be used to record the
+// measurement. If attrs is already accounted for in measurements, or the
+// limit will not be exceeded, attrs is returned unchanged. Otherwise, the
+// overflowSet is returned.
+func (l limiter[V]) Attributes(attrs attribute.Set, measurements map[attribute.Set]V) attribute.Set {
+	if l.aggLimit > 0 {
+		_, exists := measurements[attrs]
+		if !exists && len(measurements) >= l.aggLimit-1 {
+			return overflowSet
+		}
+	}
+
+	return attrs
+}
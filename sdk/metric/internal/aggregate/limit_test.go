@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestLimiterUnlimited(t *testing.T) {
+	l := newLimiter[int](0)
+	measurements := map[attribute.Set]int{}
+
+	a := attribute.NewSet(attribute.String("a", "1"))
+	assert.Equal(t, a, l.Attributes(a, measurements))
+}
+
+func TestLimiterExistingSetAlwaysAllowed(t *testing.T) {
+	l := newLimiter[int](1)
+	a := attribute.NewSet(attribute.String("a", "1"))
+	measurements := map[attribute.Set]int{a: 1}
+
+	// a is already tracked, so it is returned unchanged even though the
+	// limit of 1 is already met.
+	assert.Equal(t, a, l.Attributes(a, measurements))
+}
+
+func TestLimiterOverflow(t *testing.T) {
+	l := newLimiter[int](2)
+	a := attribute.NewSet(attribute.String("a", "1"))
+	b := attribute.NewSet(attribute.String("a", "2"))
+	measurements := map[attribute.Set]int{a: 1}
+
+	// The limit of 2 (the tracked set plus the reserved overflow bucket) is
+	// already met by a, so a new set overflows.
+	assert.Equal(t, overflowSet, l.Attributes(b, measurements))
+}
+
+func TestLimiterFirstSetUnderLimit(t *testing.T) {
+	l := newLimiter[int](2)
+	a := attribute.NewSet(attribute.String("a", "1"))
+	measurements := map[attribute.Set]int{}
+
+	// No sets are tracked yet, so the very first one is kept when the limit
+	// leaves room for it.
+	assert.Equal(t, a, l.Attributes(a, measurements))
+}
+
+// TestLimiterReservesOverflowBucket documents that aggLimit accounts for the
+// reserved overflow attribute set as one of its own slots: a limit of 1
+// leaves no room for any real attribute set, so even the very first
+// measurement overflows.
+func TestLimiterReservesOverflowBucket(t *testing.T) {
+	l := newLimiter[int](1)
+	a := attribute.NewSet(attribute.String("a", "1"))
+	measurements := map[attribute.Set]int{}
+
+	assert.Equal(t, overflowSet, l.Attributes(a, measurements))
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exemplar // import "go.opentelemetry.io/otel/sdk/metric/internal/exemplar"
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Filter determines whether a measurement should be offered to a Reservoir.
+type Filter func(context.Context) bool
+
+// AlwaysOnFilter offers every measurement to the Reservoir.
+func AlwaysOnFilter(context.Context) bool { return true }
+
+// AlwaysOffFilter drops every measurement, meaning no exemplars are ever
+// sampled.
+func AlwaysOffFilter(context.Context) bool { return false }
+
+// TraceBasedFilter only offers measurements recorded while a sampled trace
+// span is active in the context.
+func TraceBasedFilter(ctx context.Context) bool {
+	return trace.SpanContextFromContext(ctx).IsSampled()
+}
+
+// FilterFromEnv returns the Filter configured by the
+// OTEL_METRICS_EXEMPLAR_FILTER environment variable. If the variable is
+// unset or holds an unrecognized value, TraceBasedFilter is returned, which
+// matches the OpenTelemetry specification default.
+func FilterFromEnv() Filter {
+	switch os.Getenv("OTEL_METRICS_EXEMPLAR_FILTER") {
+	case "always_on":
+		return AlwaysOnFilter
+	case "always_off":
+		return AlwaysOffFilter
+	case "trace_based":
+		return TraceBasedFilter
+	default:
+		return TraceBasedFilter
+	}
+}
+
+// FilteredReservoir wraps a Reservoir so that Offer is a no-op whenever
+// filter rejects the measurement's context.
+type FilteredReservoir[N int64 | float64] struct {
+	filter Filter
+	Reservoir[N]
+}
+
+// NewFilteredReservoir returns a Reservoir that only forwards offered
+// measurements to r when filter allows them.
+func NewFilteredReservoir[N int64 | float64](filter Filter, r Reservoir[N]) *FilteredReservoir[N] {
+	return &FilteredReservoir[N]{filter: filter, Reservoir: r}
+}
+
+func (r *FilteredReservoir[N]) Offer(ctx context.Context, t time.Time, v N, droppedAttr []attribute.KeyValue) {
+	if r.filter(ctx) {
+		r.Reservoir.Offer(ctx, t, v, droppedAttr)
+	}
+}
+
+// FilteredProvider returns a Provider whose Reservoirs only accept offered
+// measurements that pass filter. It is used to apply the
+// OTEL_METRICS_EXEMPLAR_FILTER gate to the Provider an aggregator was
+// otherwise configured to use.
+func FilteredProvider[N int64 | float64](filter Filter, provider Provider[N]) Provider[N] {
+	return func() Reservoir[N] {
+		return NewFilteredReservoir[N](filter, provider())
+	}
+}
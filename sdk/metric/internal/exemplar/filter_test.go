@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exemplar
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestAlwaysOnFilter(t *testing.T) {
+	assert.True(t, AlwaysOnFilter(context.Background()))
+	assert.True(t, AlwaysOnFilter(sampledContext()))
+}
+
+func TestAlwaysOffFilter(t *testing.T) {
+	assert.False(t, AlwaysOffFilter(context.Background()))
+	assert.False(t, AlwaysOffFilter(sampledContext()))
+}
+
+func TestTraceBasedFilter(t *testing.T) {
+	assert.False(t, TraceBasedFilter(context.Background()), "no span context in ctx")
+	assert.True(t, TraceBasedFilter(sampledContext()))
+}
+
+func TestFilterFromEnv(t *testing.T) {
+	tests := []struct {
+		value string
+		ctx   context.Context
+		want  bool
+	}{
+		{"always_on", context.Background(), true},
+		{"always_off", sampledContext(), false},
+		{"trace_based", context.Background(), false},
+		{"trace_based", sampledContext(), true},
+		{"", context.Background(), false},
+		{"unrecognized", sampledContext(), true},
+	}
+
+	for _, test := range tests {
+		t.Setenv("OTEL_METRICS_EXEMPLAR_FILTER", test.value)
+		assert.Equal(t, test.want, FilterFromEnv()(test.ctx), "value=%q", test.value)
+	}
+}
+
+func TestFilteredReservoirOffer(t *testing.T) {
+	r := NewFilteredReservoir[int64](AlwaysOffFilter, SimpleFixedSizeReservoir[int64](2)())
+	r.Offer(context.Background(), time.Time{}, 1, nil)
+
+	var got []metricdata.Exemplar[int64]
+	r.Collect(&got)
+	assert.Empty(t, got, "filter rejected every measurement")
+
+	r = NewFilteredReservoir[int64](AlwaysOnFilter, SimpleFixedSizeReservoir[int64](2)())
+	r.Offer(context.Background(), time.Time{}, 1, nil)
+	r.Collect(&got)
+	require.Len(t, got, 1)
+	assert.Equal(t, int64(1), got[0].Value)
+}
+
+func TestFilteredProvider(t *testing.T) {
+	provider := FilteredProvider[int64](AlwaysOffFilter, SimpleFixedSizeReservoir[int64](2))
+	r := provider()
+	r.Offer(context.Background(), time.Time{}, 1, nil)
+
+	var got []metricdata.Exemplar[int64]
+	r.Collect(&got)
+	assert.Empty(t, got)
+}
+
+func sampledSpanContext() trace.SpanContext {
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+	})
+}
+
+func sampledContext() context.Context {
+	return trace.ContextWithSpanContext(context.Background(), sampledSpanContext())
+}
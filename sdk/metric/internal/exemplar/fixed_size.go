@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exemplar // import "go.opentelemetry.io/otel/sdk/metric/internal/exemplar"
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// fixedSizeReservoir is a Reservoir that samples at most k exemplars using
+// Algorithm R, an unweighted reservoir sampling algorithm.
+//
+// The i-th offered measurement (1-indexed) is kept with probability k/i,
+// replacing a uniformly-chosen existing sample.
+type fixedSizeReservoir[N int64 | float64] struct {
+	store []metricdata.Exemplar[N]
+	// count is the number of measurements offered since the last Collect.
+	count int64
+}
+
+// SimpleFixedSizeReservoir returns a Provider of Reservoirs that each
+// uniformly sample, at most, k exemplars. The Reservoir is reset, starting
+// a new sampling window, each time it is collected.
+func SimpleFixedSizeReservoir[N int64 | float64](k int) Provider[N] {
+	return func() Reservoir[N] {
+		return &fixedSizeReservoir[N]{store: make([]metricdata.Exemplar[N], 0, k)}
+	}
+}
+
+func (r *fixedSizeReservoir[N]) Offer(ctx context.Context, t time.Time, v N, droppedAttr []attribute.KeyValue) {
+	r.count++
+
+	if len(r.store) < cap(r.store) {
+		r.store = append(r.store, newExemplar(ctx, t, v, droppedAttr))
+		return
+	}
+
+	// Keep the new measurement with probability cap(r.store)/r.count,
+	// replacing a uniformly chosen existing exemplar.
+	if idx := rand.Int63n(r.count); idx < int64(cap(r.store)) {
+		r.store[idx] = newExemplar(ctx, t, v, droppedAttr)
+	}
+}
+
+func (r *fixedSizeReservoir[N]) Collect(dest *[]metricdata.Exemplar[N]) {
+	*dest = append((*dest)[:0], r.store...)
+	r.store = r.store[:0]
+	r.count = 0
+}
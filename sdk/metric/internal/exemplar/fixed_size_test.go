@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exemplar
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestFixedSizeReservoirCapacity(t *testing.T) {
+	r := SimpleFixedSizeReservoir[int64](3)()
+
+	for i := int64(0); i < 10; i++ {
+		r.Offer(context.Background(), time.Time{}, i, nil)
+	}
+
+	var got []metricdata.Exemplar[int64]
+	r.Collect(&got)
+	assert.Len(t, got, 3)
+}
+
+func TestFixedSizeReservoirUnderCapacityKeepsAll(t *testing.T) {
+	r := SimpleFixedSizeReservoir[int64](5)()
+
+	for i := int64(0); i < 3; i++ {
+		r.Offer(context.Background(), time.Time{}, i, nil)
+	}
+
+	var got []metricdata.Exemplar[int64]
+	r.Collect(&got)
+	require.Len(t, got, 3)
+
+	seen := make(map[int64]bool)
+	for _, e := range got {
+		seen[e.Value] = true
+	}
+	assert.Len(t, seen, 3)
+}
+
+func TestFixedSizeReservoirResetsOnCollect(t *testing.T) {
+	r := SimpleFixedSizeReservoir[int64](2)()
+
+	r.Offer(context.Background(), time.Time{}, 1, nil)
+	r.Offer(context.Background(), time.Time{}, 2, nil)
+
+	var got []metricdata.Exemplar[int64]
+	r.Collect(&got)
+	assert.Len(t, got, 2)
+
+	// Nothing offered since the last collection, so the next collection is
+	// empty.
+	r.Collect(&got)
+	assert.Empty(t, got)
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exemplar // import "go.opentelemetry.io/otel/sdk/metric/internal/exemplar"
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// alignedHistogramBucketReservoir is a Reservoir that keeps, at most, one
+// exemplar per histogram bucket. The most recent measurement offered to a
+// bucket always overwrites any previously held exemplar for that bucket.
+type alignedHistogramBucketReservoir[N int64 | float64] struct {
+	bounds []float64
+	store  []*metricdata.Exemplar[N]
+}
+
+// AlignedHistogramBucketReservoir returns a Provider of Reservoirs that
+// keep the most recently offered measurement for each of the histogram
+// buckets defined by bounds.
+func AlignedHistogramBucketReservoir[N int64 | float64](bounds []float64) Provider[N] {
+	b := make([]float64, len(bounds))
+	copy(b, bounds)
+	sort.Float64s(b)
+	return func() Reservoir[N] {
+		return &alignedHistogramBucketReservoir[N]{
+			bounds: b,
+			store:  make([]*metricdata.Exemplar[N], len(b)+1),
+		}
+	}
+}
+
+func (r *alignedHistogramBucketReservoir[N]) Offer(ctx context.Context, t time.Time, v N, droppedAttr []attribute.KeyValue) {
+	idx := sort.SearchFloat64s(r.bounds, float64(v))
+	e := newExemplar(ctx, t, v, droppedAttr)
+	r.store[idx] = &e
+}
+
+func (r *alignedHistogramBucketReservoir[N]) Collect(dest *[]metricdata.Exemplar[N]) {
+	*dest = (*dest)[:0]
+	for _, e := range r.store {
+		if e == nil {
+			continue
+		}
+		*dest = append(*dest, *e)
+	}
+	for i := range r.store {
+		r.store[i] = nil
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exemplar
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestAlignedHistogramBucketReservoirAligns(t *testing.T) {
+	r := AlignedHistogramBucketReservoir[int64]([]float64{0, 10})()
+
+	r.Offer(context.Background(), time.Time{}, -1, nil)
+	r.Offer(context.Background(), time.Time{}, 5, nil)
+	r.Offer(context.Background(), time.Time{}, 20, nil)
+
+	var got []metricdata.Exemplar[int64]
+	r.Collect(&got)
+	require.Len(t, got, 3)
+
+	values := make(map[int64]bool)
+	for _, e := range got {
+		values[e.Value] = true
+	}
+	assert.True(t, values[-1])
+	assert.True(t, values[5])
+	assert.True(t, values[20])
+}
+
+func TestAlignedHistogramBucketReservoirOverwritesBucket(t *testing.T) {
+	r := AlignedHistogramBucketReservoir[int64]([]float64{0, 10})()
+
+	r.Offer(context.Background(), time.Time{}, 5, nil)
+	r.Offer(context.Background(), time.Time{}, 6, nil)
+
+	var got []metricdata.Exemplar[int64]
+	r.Collect(&got)
+	require.Len(t, got, 1, "the second offer to the same bucket replaces the first")
+	assert.Equal(t, int64(6), got[0].Value)
+}
+
+func TestAlignedHistogramBucketReservoirResetsOnCollect(t *testing.T) {
+	r := AlignedHistogramBucketReservoir[int64]([]float64{0, 10})()
+
+	r.Offer(context.Background(), time.Time{}, 5, nil)
+
+	var got []metricdata.Exemplar[int64]
+	r.Collect(&got)
+	assert.Len(t, got, 1)
+
+	r.Collect(&got)
+	assert.Empty(t, got)
+}
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package exemplar provides the sampling reservoirs the metric SDK uses to
+// attach representative measurements to aggregated data points.
+package exemplar // import "go.opentelemetry.io/otel/sdk/metric/internal/exemplar"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Reservoir holds the sampled exemplars for a single aggregated data point.
+// Implementations are not expected to be safe for concurrent use; the
+// aggregator holding a Reservoir is responsible for synchronizing access to
+// it.
+type Reservoir[N int64 | float64] interface {
+	// Offer accepts a measurement to be held as a possible exemplar. t is
+	// the time the measurement was recorded, v is its value, and
+	// droppedAttr are the attributes that were dropped by the aggregation's
+	// attribute filter (and would otherwise be lost).
+	//
+	// The span context active in ctx, if any, is recorded alongside the
+	// measurement.
+	Offer(ctx context.Context, t time.Time, v N, droppedAttr []attribute.KeyValue)
+
+	// Collect replaces the contents of dest with the held exemplars and
+	// resets the Reservoir for the next collection cycle.
+	Collect(dest *[]metricdata.Exemplar[N])
+}
+
+// Provider returns a new, empty Reservoir to be used by a single
+// aggregation data point.
+type Provider[N int64 | float64] func() Reservoir[N]
+
+// newExemplar returns a new Exemplar recording v at t, along with the
+// dropped attributes and the span context (if any) held in ctx.
+func newExemplar[N int64 | float64](ctx context.Context, t time.Time, v N, droppedAttr []attribute.KeyValue) metricdata.Exemplar[N] {
+	sc := trace.SpanContextFromContext(ctx)
+	return metricdata.Exemplar[N]{
+		FilteredAttributes: droppedAttr,
+		TraceID:            sc.TraceID(),
+		SpanID:             sc.SpanID(),
+		Time:               t,
+		Value:              v,
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exemplar
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestNewExemplarNoSpanContext(t *testing.T) {
+	ts := time.Now()
+	dropped := []attribute.KeyValue{attribute.String("a", "1")}
+
+	e := newExemplar[int64](context.Background(), ts, 5, dropped)
+
+	assert.Equal(t, int64(5), e.Value)
+	assert.Equal(t, ts, e.Time)
+	assert.Equal(t, dropped, e.FilteredAttributes)
+	assert.False(t, e.TraceID.IsValid())
+	assert.False(t, e.SpanID.IsValid())
+}
+
+func TestNewExemplarRecordsSpanContext(t *testing.T) {
+	ts := time.Now()
+
+	e := newExemplar[int64](sampledContext(), ts, 5, nil)
+
+	sc := sampledSpanContext()
+	assert.Equal(t, sc.TraceID(), e.TraceID)
+	assert.Equal(t, sc.SpanID(), e.SpanID)
+}
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package x contains support for OTel metric SDK experimental features.
+//
+// This package is periodically cleared, all features contained in it are
+// graduated, removed, or otherwise modified as part of the SDK's release
+// process. No feature here is guaranteed a stable lifetime outside of a
+// single minor version.
+package x // import "go.opentelemetry.io/otel/sdk/metric/internal/x"
+
+import (
+	"os"
+	"strconv"
+)
+
+// CardinalityLimit is the flag to enable the cardinality limit for the
+// SDK's metric export.
+//
+// To enable this feature set the OTEL_GO_X_CARDINALITY_LIMIT environment
+// variable to the integer limit you want to use.
+var CardinalityLimit = newFeature("CARDINALITY_LIMIT", func(v string) (int, bool) {
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	return n, true
+})
+
+// Exemplars is the flag to enable exemplar recording.
+//
+// To enable this feature set the OTEL_GO_X_EXEMPLARS environment variable
+// to a value strconv.ParseBool reads as true ("1", "t", "T", "true", "True"
+// or "TRUE").
+var Exemplars = newFeature("EXEMPLARS", func(v string) (bool, bool) {
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, false
+	}
+	return b, true
+})
+
+// ExponentialHistogram is the flag to enable exponential histogram
+// aggregation.
+//
+// To enable this feature set the OTEL_GO_X_EXPONENTIAL_HISTOGRAM
+// environment variable to a value strconv.ParseBool reads as true ("1",
+// "t", "T", "true", "True" or "TRUE").
+var ExponentialHistogram = newFeature("EXPONENTIAL_HISTOGRAM", func(v string) (bool, bool) {
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, false
+	}
+	return b, true
+})
+
+// flag is satisfied by every Feature[T], regardless of its value type, so
+// the SDK can enumerate the known experimental features without caring
+// what they parse to.
+type flag interface {
+	Enabled() bool
+}
+
+// features is the registry of every experimental feature flag the SDK
+// recognizes. Enabled reads it to report which experimental features are
+// active; nothing in the SDK logs that report when a MeterProvider starts.
+var features = map[string]flag{
+	CardinalityLimit.Key:     CardinalityLimit,
+	Exemplars.Key:            Exemplars,
+	ExponentialHistogram.Key: ExponentialHistogram,
+}
+
+// Enabled returns the subset of the known experimental feature flags that
+// are currently enabled, keyed by their environment variable name.
+func Enabled() map[string]bool {
+	out := make(map[string]bool, len(features))
+	for key, f := range features {
+		if f.Enabled() {
+			out[key] = true
+		}
+	}
+	return out
+}
+
+// Feature is an experimental feature control flag. It provides a uniform
+// way to interact with these feature flags and parse their values.
+type Feature[T any] struct {
+	Key string
+
+	parse func(v string) (T, bool)
+}
+
+func newFeature[T any](suffix string, parse func(string) (T, bool)) Feature[T] {
+	const envKeyRoot = "OTEL_GO_X_"
+	return Feature[T]{
+		Key:   envKeyRoot + suffix,
+		parse: parse,
+	}
+}
+
+// Lookup returns the user configured value for the feature and true if the
+// user has set the value. Otherwise, if the feature is not set, the zero
+// value and false are returned.
+func (f Feature[T]) Lookup() (v T, ok bool) {
+	value := os.Getenv(f.Key)
+	if value == "" {
+		return v, ok
+	}
+	return f.parse(value)
+}
+
+// Enabled returns if the feature is enabled. A boolean feature set to a
+// false value is not enabled.
+func (f Feature[T]) Enabled() bool {
+	v, ok := f.Lookup()
+	if b, isBool := any(v).(bool); isBool {
+		return ok && b
+	}
+	return ok
+}
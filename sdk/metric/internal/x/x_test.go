@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package x
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCardinalityLimit(t *testing.T) {
+	t.Setenv(CardinalityLimit.Key, "")
+	assert.False(t, CardinalityLimit.Enabled())
+
+	t.Setenv(CardinalityLimit.Key, "not-a-number")
+	_, ok := CardinalityLimit.Lookup()
+	assert.False(t, ok)
+
+	t.Setenv(CardinalityLimit.Key, "-1")
+	_, ok = CardinalityLimit.Lookup()
+	assert.False(t, ok, "negative limits are invalid")
+
+	t.Setenv(CardinalityLimit.Key, "2000")
+	v, ok := CardinalityLimit.Lookup()
+	assert.True(t, ok)
+	assert.Equal(t, 2000, v)
+}
+
+func TestBoolFeatures(t *testing.T) {
+	for _, f := range []Feature[bool]{Exemplars, ExponentialHistogram} {
+		t.Setenv(f.Key, "")
+		assert.False(t, f.Enabled())
+
+		t.Setenv(f.Key, "true")
+		assert.True(t, f.Enabled())
+
+		t.Setenv(f.Key, "false")
+		v, ok := f.Lookup()
+		assert.True(t, ok)
+		assert.False(t, v)
+	}
+}
+
+func TestEnabledReportsActiveFeatures(t *testing.T) {
+	t.Setenv(CardinalityLimit.Key, "")
+	t.Setenv(Exemplars.Key, "")
+	t.Setenv(ExponentialHistogram.Key, "true")
+
+	active := Enabled()
+	assert.Equal(t, map[string]bool{ExponentialHistogram.Key: true}, active)
+}
@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricdata // import "go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Exemplar is a measurement sampled from a timeseries providing a typical
+// example.
+type Exemplar[N int64 | float64] struct {
+	// FilteredAttributes are the attributes recorded with the measurement
+	// but filtered out of the timeseries' aggregated data.
+	FilteredAttributes []attribute.KeyValue
+	// TraceID is the ID of the trace the measurement was recorded in if one
+	// was active.
+	TraceID trace.TraceID
+	// SpanID is the ID of the span the measurement was recorded in if one
+	// was active.
+	SpanID trace.SpanID
+	// Time is the time the measurement was recorded.
+	Time time.Time
+	// Value is the measured value.
+	Value N
+}